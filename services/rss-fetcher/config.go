@@ -10,15 +10,18 @@ import (
 )
 
 type FeedConfig struct {
-	URL      string `yaml:"url"`
-	Outlet   string `yaml:"outlet"`
-	Interval int    `yaml:"interval"`
-	Category string `yaml:"category"`
+	URL               string `yaml:"url"`
+	Outlet            string `yaml:"outlet"`
+	Interval          int    `yaml:"interval"`
+	Category          string `yaml:"category"`
+	PreferredExtractor string `yaml:"preferred_extractor"`
 }
 
 type FeedsConfig struct {
-	Feeds    []FeedConfig    `yaml:"feeds"`
-	Defaults DefaultsConfig  `yaml:"defaults"`
+	Feeds              []FeedConfig   `yaml:"feeds"`
+	Defaults           DefaultsConfig `yaml:"defaults"`
+	RelevanceThreshold float64        `yaml:"relevance_threshold"`
+	Stopwords          []string       `yaml:"stopwords"`
 }
 
 type DefaultsConfig struct {
@@ -62,6 +65,9 @@ func LoadFeedsConfig(path string) (*FeedsConfig, error) {
 			}
 		}
 	}
+	if config.RelevanceThreshold == 0 {
+		config.RelevanceThreshold = defaultRelevanceThreshold
+	}
 
 	return &config, nil
 }
@@ -95,6 +101,7 @@ func getDefaultConfig() *FeedsConfig {
 			Retries:   3,
 			UserAgent: userAgent,
 		},
+		RelevanceThreshold: defaultRelevanceThreshold,
 	}
 }
 
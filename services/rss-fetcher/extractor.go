@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// minExtractedWords and minTextToHTMLRatio gate whether an extractor's
+// result is "good enough" to stop trying the next one in the chain.
+const (
+	minExtractedWords    = 80
+	minTextToHTMLRatio   = 0.02
+)
+
+// ArticleExtractor pulls readable article content out of a fetched HTML
+// document. Implementations must not assume any particular site layout
+// and should return a low-confidence (short) result rather than an
+// error when they can't find a good candidate, so the caller can fall
+// through to the next extractor in the chain.
+type ArticleExtractor interface {
+	// Name identifies the extractor for FeedConfig.PreferredExtractor and logging.
+	Name() string
+	// Extract returns the best-effort article content from doc/rawHTML.
+	Extract(doc *goquery.Document, rawHTML string) (*Article, error)
+}
+
+// quality scores how usable an extracted article is, used to decide
+// whether to accept it or fall through to the next extractor.
+func quality(a *Article, rawHTMLLen int) float64 {
+	words := len(strings.Fields(a.Text))
+	if words < minExtractedWords {
+		return 0
+	}
+	if rawHTMLLen == 0 {
+		return 0
+	}
+	ratio := float64(len(a.Text)) / float64(rawHTMLLen)
+	if ratio < minTextToHTMLRatio {
+		return 0
+	}
+	return ratio * float64(words)
+}
+
+// selectorExtractor is the original hardcoded CSS-selector approach,
+// kept as the reliable fallback when the smarter extractors can't find
+// a confident candidate.
+type selectorExtractor struct{}
+
+func (selectorExtractor) Name() string { return "selector" }
+
+func (selectorExtractor) Extract(doc *goquery.Document, rawHTML string) (*Article, error) {
+	text := ""
+	doc.Find("article, main, .content, .article-content, .post-content, p").Each(func(i int, s *goquery.Selection) {
+		text += s.Text() + "\n"
+	})
+
+	if strings.TrimSpace(text) == "" {
+		text = doc.Find("body").Text()
+	}
+
+	return &Article{Text: cleanText(text), Author: extractMetaAuthor(doc)}, nil
+}
+
+// readabilityExtractor scores candidate block elements by text
+// density, similar to Mozilla's Readability algorithm: characters per
+// tag, a penalty for link-heavy blocks (nav/related-article rails),
+// and a bonus for prose-like comma usage. It returns the single
+// highest-scoring subtree instead of concatenating every match, which
+// is what keeps nav/related-article noise out.
+type readabilityExtractor struct{}
+
+func (readabilityExtractor) Name() string { return "readability" }
+
+func (readabilityExtractor) Extract(doc *goquery.Document, rawHTML string) (*Article, error) {
+	var best *goquery.Selection
+	var bestScore float64
+
+	doc.Find("div, section, article").Each(func(i int, s *goquery.Selection) {
+		text := strings.TrimSpace(s.Text())
+		if len(text) < 200 {
+			return
+		}
+
+		tagCount := s.Find("*").Length() + 1
+		linkTextLen := 0
+		s.Find("a").Each(func(_ int, a *goquery.Selection) {
+			linkTextLen += len(a.Text())
+		})
+
+		linkDensity := float64(linkTextLen) / float64(len(text)+1)
+		commaBonus := 1.0 + float64(strings.Count(text, ","))*0.1
+		score := (float64(len(text)) / float64(tagCount)) * (1 - linkDensity) * commaBonus
+
+		if score > bestScore {
+			bestScore = score
+			best = s
+		}
+	})
+
+	if best == nil {
+		return &Article{}, nil
+	}
+
+	return &Article{Text: cleanText(best.Text()), Author: extractMetaAuthor(doc)}, nil
+}
+
+// jsonLDExtractor reads a NewsArticle JSON-LD block and/or OpenGraph
+// meta tags, which modern CMSes populate even when the visible DOM is
+// full of paywall/teaser noise.
+type jsonLDExtractor struct{}
+
+func (jsonLDExtractor) Name() string { return "jsonld" }
+
+type newsArticleLD struct {
+	Type          string      `json:"@type"`
+	Headline      string      `json:"headline"`
+	ArticleBody   string      `json:"articleBody"`
+	DatePublished string      `json:"datePublished"`
+	Author        interface{} `json:"author"`
+}
+
+func (jsonLDExtractor) Extract(doc *goquery.Document, rawHTML string) (*Article, error) {
+	var article Article
+
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var ld newsArticleLD
+		if err := json.Unmarshal([]byte(s.Text()), &ld); err != nil {
+			return true // keep looking at other script blocks
+		}
+		if !strings.Contains(ld.Type, "Article") || ld.ArticleBody == "" {
+			return true
+		}
+		article.Text = cleanText(ld.ArticleBody)
+		if name := authorNameFromLD(ld.Author); name != "" {
+			article.Author = &name
+		}
+		return false
+	})
+
+	if article.Text == "" {
+		if og, ok := doc.Find(`meta[property="og:description"]`).Attr("content"); ok {
+			article.Text = cleanText(og)
+		}
+	}
+	if article.Author == nil {
+		article.Author = extractMetaAuthor(doc)
+	}
+
+	return &article, nil
+}
+
+func authorNameFromLD(raw interface{}) string {
+	switch v := raw.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok {
+			return name
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			return authorNameFromLD(v[0])
+		}
+	}
+	return ""
+}
+
+// cleanText normalizes extracted text the same way the original
+// selector-based extractor did: trim each line, drop short noise
+// lines, and cap overall length.
+func cleanText(text string) string {
+	lines := strings.Split(text, "\n")
+	var cleanLines []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line != "" && len(line) > 10 {
+			cleanLines = append(cleanLines, line)
+		}
+	}
+	text = strings.Join(cleanLines, "\n")
+
+	if len(text) > 50000 {
+		text = text[:50000]
+	}
+	return text
+}
+
+func extractMetaAuthor(doc *goquery.Document) *string {
+	if authorText, exists := doc.Find(`meta[name="author"]`).Attr("content"); exists && authorText != "" {
+		return &authorText
+	}
+	if authorText, exists := doc.Find(`meta[property="article:author"]`).Attr("content"); exists && authorText != "" {
+		return &authorText
+	}
+	if authorText := doc.Find(".author, .byline, [rel='author']").First().Text(); authorText != "" {
+		cleanAuthor := strings.TrimSpace(authorText)
+		if cleanAuthor != "" {
+			return &cleanAuthor
+		}
+	}
+	return nil
+}
+
+// defaultExtractorChain is tried in order until one produces an
+// acceptable-quality result. JSON-LD/OpenGraph first since it's the
+// cleanest signal when present, then Readability's density scoring,
+// falling back to the original selector list.
+func defaultExtractorChain() []ArticleExtractor {
+	return []ArticleExtractor{jsonLDExtractor{}, readabilityExtractor{}, selectorExtractor{}}
+}
+
+// extractorByName looks up a named extractor for FeedConfig.PreferredExtractor,
+// used to move it to the front of the chain.
+func extractorByName(name string) ArticleExtractor {
+	for _, e := range defaultExtractorChain() {
+		if e.Name() == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// runExtractorChain tries each extractor in order and returns the
+// first result meeting the minimum quality bar, falling back to the
+// last extractor's result if none clear it.
+func runExtractorChain(chain []ArticleExtractor, doc *goquery.Document, rawHTML string) (*Article, error) {
+	var last *Article
+	for _, extractor := range chain {
+		article, err := extractor.Extract(doc, rawHTML)
+		if err != nil {
+			return nil, fmt.Errorf("extractor %s failed: %w", extractor.Name(), err)
+		}
+		last = article
+		if quality(article, len(rawHTML)) > 0 {
+			return article, nil
+		}
+	}
+	return last, nil
+}
+
+// preferredChain reorders the default extractor chain so preferredName
+// (from FeedConfig.PreferredExtractor) is tried first, if recognized.
+func preferredChain(preferredName string) []ArticleExtractor {
+	chain := defaultExtractorChain()
+	preferred := extractorByName(preferredName)
+	if preferred == nil {
+		return chain
+	}
+
+	reordered := []ArticleExtractor{preferred}
+	for _, e := range chain {
+		if e.Name() != preferred.Name() {
+			reordered = append(reordered, e)
+		}
+	}
+	return reordered
+}
+
+func fetchHTML(client *http.Client, url string) (*goquery.Document, string, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch article: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	// JSON-LD blocks are kept: jsonLDExtractor reads them from doc.
+	doc.Find("style, nav, header, footer, aside").Remove()
+	doc.Find("script").Not(`[type="application/ld+json"]`).Remove()
+
+	html, _ := doc.Html()
+	if len(html) > 100000 {
+		html = html[:100000]
+	}
+
+	return doc, html, nil
+}
@@ -1,35 +1,84 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/go-co-op/gocron"
 	_ "github.com/lib/pq"
 	"github.com/mmcdole/gofeed"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 )
 
 type RSSFetcher struct {
-	db     *sql.DB
-	client *http.Client
-	parser *gofeed.Parser
-	log    *logrus.Logger
+	db           *sql.DB
+	client       *http.Client
+	parser       *gofeed.Parser
+	log          *logrus.Logger
+	config       *FeedsConfig
+	scorerMu     sync.RWMutex
+	scorer       *relevanceScorer
+	hostLimits   *hostLimiters
+	workerPoolSize int
+}
+
+// getScorer returns the cached relevance scorer, lazily loading it from
+// term_df if this is the first call. The worker pool (chunk0-4) runs
+// SaveArticle/LinkArticleToEvents for many feeds concurrently, and the
+// nightly gocron reload (see RecomputeTermFrequencies's caller) swaps
+// the scorer from yet another goroutine, so both the read and the
+// lazy-init write go through scorerMu.
+func (f *RSSFetcher) getScorer() (*relevanceScorer, error) {
+	f.scorerMu.RLock()
+	scorer := f.scorer
+	f.scorerMu.RUnlock()
+	if scorer != nil {
+		return scorer, nil
+	}
+
+	f.scorerMu.Lock()
+	defer f.scorerMu.Unlock()
+	if f.scorer != nil {
+		return f.scorer, nil
+	}
+	scorer, err := f.loadDocumentFrequencies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load term frequencies: %w", err)
+	}
+	f.scorer = scorer
+	return scorer, nil
+}
+
+// setScorer replaces the cached relevance scorer, used by the nightly
+// term_df recompute job.
+func (f *RSSFetcher) setScorer(scorer *relevanceScorer) {
+	f.scorerMu.Lock()
+	f.scorer = scorer
+	f.scorerMu.Unlock()
 }
 
 type RSSFeed struct {
-	ID                   int       `json:"id"`
-	URL                  string    `json:"url"`
-	OutletName           string    `json:"outlet_name"`
+	ID                   int        `json:"id"`
+	URL                  string     `json:"url"`
+	OutletName           string     `json:"outlet_name"`
 	LastFetched          *time.Time `json:"last_fetched"`
-	FetchIntervalMinutes *int      `json:"fetch_interval_minutes"`
+	FetchIntervalMinutes *int       `json:"fetch_interval_minutes"`
+	NewestUnixTime       *int64     `json:"newest_unix_time"`
+	LastSeenGUID         *string    `json:"last_seen_guid"`
+	ETag                 *string    `json:"etag"`
+	LastModified         *string    `json:"last_modified"`
+	ConsecutiveFailures  int        `json:"consecutive_failures"`
 }
 
 type Article struct {
@@ -77,11 +126,24 @@ func NewRSSFetcher() (*RSSFetcher, error) {
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
 
+	config, err := LoadFeedsConfig(os.Getenv("FEEDS_CONFIG_PATH"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load feeds config: %w", err)
+	}
+
+	workerPoolSize := defaultWorkerPoolSize
+	if n, err := strconv.Atoi(os.Getenv("WORKER_POOL_SIZE")); err == nil && n > 0 {
+		workerPoolSize = n
+	}
+
 	return &RSSFetcher{
-		db:     db,
-		client: client,
-		parser: parser,
-		log:    logger,
+		db:             db,
+		client:         client,
+		parser:         parser,
+		log:            logger,
+		config:         config,
+		hostLimits:     newHostLimiters(defaultHostRPS, defaultHostBurst),
+		workerPoolSize: workerPoolSize,
 	}, nil
 }
 
@@ -93,11 +155,12 @@ func (f *RSSFetcher) Close() {
 
 func (f *RSSFetcher) GetActiveFeeds() ([]RSSFeed, error) {
 	query := `
-		SELECT id, url, outlet_name, last_fetched, fetch_interval_minutes 
-		FROM rss_feeds 
+		SELECT id, url, outlet_name, last_fetched, fetch_interval_minutes,
+		       newest_unix_time, last_seen_guid, etag, last_modified, consecutive_failures
+		FROM rss_feeds
 		WHERE active = TRUE
 	`
-	
+
 	rows, err := f.db.Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query active feeds: %w", err)
@@ -113,6 +176,11 @@ func (f *RSSFetcher) GetActiveFeeds() ([]RSSFeed, error) {
 			&feed.OutletName,
 			&feed.LastFetched,
 			&feed.FetchIntervalMinutes,
+			&feed.NewestUnixTime,
+			&feed.LastSeenGUID,
+			&feed.ETag,
+			&feed.LastModified,
+			&feed.ConsecutiveFailures,
 		)
 		if err != nil {
 			f.log.WithError(err).Error("Failed to scan feed row")
@@ -124,7 +192,18 @@ func (f *RSSFetcher) GetActiveFeeds() ([]RSSFeed, error) {
 	return feeds, rows.Err()
 }
 
+// ShouldFetchFeed reports whether feed is due for a polled fetch. A
+// feed with a live WebSub push subscription is skipped entirely - push
+// is meant as an alternative to polling, not an addition to it - and
+// falls back to polling again if the subscription lapses or was never
+// established.
 func (f *RSSFetcher) ShouldFetchFeed(feed RSSFeed) bool {
+	if active, err := f.hasActiveSubscription(feed.ID); err != nil {
+		f.log.WithError(err).Error("Failed to check WebSub subscription state")
+	} else if active {
+		return false
+	}
+
 	if feed.LastFetched == nil {
 		return true
 	}
@@ -134,99 +213,157 @@ func (f *RSSFetcher) ShouldFetchFeed(feed RSSFeed) bool {
 		interval = *feed.FetchIntervalMinutes
 	}
 
+	interval = backoffInterval(interval, feed.ConsecutiveFailures)
+
 	nextFetch := feed.LastFetched.Add(time.Duration(interval) * time.Minute)
 	return time.Now().UTC().After(nextFetch)
 }
 
-func (f *RSSFetcher) ParseFeed(feedURL string) (*gofeed.Feed, error) {
-	f.log.WithField("url", feedURL).Debug("Parsing RSS feed")
-	
+// backoffInterval doubles the base polling interval for each
+// consecutive failure, up to maxBackoffMinutes, so a dead feed is
+// polled less and less often instead of hammering it every cycle.
+func backoffInterval(baseMinutes, consecutiveFailures int) int {
+	if consecutiveFailures <= 0 {
+		return baseMinutes
+	}
+
+	interval := baseMinutes
+	for i := 0; i < consecutiveFailures && interval < maxBackoffMinutes; i++ {
+		interval *= 2
+	}
+	if interval > maxBackoffMinutes {
+		interval = maxBackoffMinutes
+	}
+	return interval
+}
+
+// ParseFeed conditionally fetches feed.URL using the feed's stored
+// ETag/Last-Modified, returning notModified=true on a 304 without
+// invoking the gofeed parser. On success it persists the new
+// ETag/Last-Modified and resets consecutive_failures; on a fetch error
+// it increments consecutive_failures so ShouldFetchFeed can back off.
+// It also passes any WebSub hub URL advertised by the feed to
+// maybeSubscribeWebSub so it can switch the feed to push delivery.
+func (f *RSSFetcher) ParseFeed(feed RSSFeed) (parsed *gofeed.Feed, notModified bool, err error) {
+	f.log.WithField("url", feed.URL).Debug("Parsing RSS feed")
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	feed, err := f.parser.ParseURLWithContext(feedURL, ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse feed %s: %w", feedURL, err)
+	if err := f.hostLimits.wait(ctx, feed.URL); err != nil {
+		return nil, false, fmt.Errorf("rate limiter wait failed: %w", err)
 	}
 
-	return feed, nil
-}
-
-func (f *RSSFetcher) ExtractArticleContent(url string) (*Article, error) {
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feed.URL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to build request for %s: %w", feed.URL, err)
 	}
-	
 	req.Header.Set("User-Agent", userAgent)
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	if feed.ETag != nil && *feed.ETag != "" {
+		req.Header.Set("If-None-Match", *feed.ETag)
+	}
+	if feed.LastModified != nil && *feed.LastModified != "" {
+		req.Header.Set("If-Modified-Since", *feed.LastModified)
+	}
 
 	resp, err := f.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch article: %w", err)
+		if updErr := f.recordFeedFailure(feed.ID); updErr != nil {
+			f.log.WithError(updErr).Error("Failed to record feed failure")
+		}
+		return nil, false, fmt.Errorf("failed to fetch feed %s: %w", feed.URL, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		if err := f.recordFeedSuccess(feed.ID, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")); err != nil {
+			f.log.WithError(err).Error("Failed to record feed success")
+		}
+		return nil, true, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		if updErr := f.recordFeedFailure(feed.ID); updErr != nil {
+			f.log.WithError(updErr).Error("Failed to record feed failure")
+		}
+		return nil, false, fmt.Errorf("unexpected status %d fetching feed %s", resp.StatusCode, feed.URL)
 	}
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		if updErr := f.recordFeedFailure(feed.ID); updErr != nil {
+			f.log.WithError(updErr).Error("Failed to record feed failure")
+		}
+		return nil, false, fmt.Errorf("failed to read feed body %s: %w", feed.URL, err)
 	}
 
-	// Extract text content
-	doc.Find("script, style, nav, header, footer, aside").Remove()
-	
-	text := ""
-	doc.Find("article, main, .content, .article-content, .post-content, p").Each(func(i int, s *goquery.Selection) {
-		text += s.Text() + "\n"
-	})
+	parsedFeed, err := f.parser.Parse(bytes.NewReader(body))
+	if err != nil {
+		if updErr := f.recordFeedFailure(feed.ID); updErr != nil {
+			f.log.WithError(updErr).Error("Failed to record feed failure")
+		}
+		return nil, false, fmt.Errorf("failed to parse feed %s: %w", feed.URL, err)
+	}
 
-	// Fallback to body if no specific content found
-	if strings.TrimSpace(text) == "" {
-		text = doc.Find("body").Text()
+	if err := f.recordFeedSuccess(feed.ID, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")); err != nil {
+		f.log.WithError(err).Error("Failed to record feed success")
 	}
 
-	// Clean up text
-	lines := strings.Split(text, "\n")
-	var cleanLines []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" && len(line) > 10 { // Filter out very short lines
-			cleanLines = append(cleanLines, line)
-		}
+	if hubURL := discoverHubURL(body); hubURL != "" {
+		f.maybeSubscribeWebSub(feed, hubURL)
+	}
+
+	return parsedFeed, false, nil
+}
+
+func (f *RSSFetcher) recordFeedSuccess(feedID int, etag, lastModified string) error {
+	_, err := f.db.Exec(`
+		UPDATE rss_feeds
+		SET etag = NULLIF($2, ''), last_modified = NULLIF($3, ''), consecutive_failures = 0
+		WHERE id = $1
+	`, feedID, etag, lastModified)
+	return err
+}
+
+func (f *RSSFetcher) recordFeedFailure(feedID int) error {
+	_, err := f.db.Exec(`
+		UPDATE rss_feeds SET consecutive_failures = consecutive_failures + 1 WHERE id = $1
+	`, feedID)
+	return err
+}
+
+// ExtractArticleContent fetches url and runs it through the extractor
+// chain (JSON-LD/OpenGraph, then Readability-style density scoring,
+// then the selector-based fallback), preferring preferredExtractor
+// if the feed's config names one.
+func (f *RSSFetcher) ExtractArticleContent(url, preferredExtractor string) (*Article, error) {
+	if err := f.hostLimits.wait(context.Background(), url); err != nil {
+		return nil, fmt.Errorf("rate limiter wait failed: %w", err)
 	}
-	text = strings.Join(cleanLines, "\n")
 
-	// Limit text length
-	if len(text) > 50000 {
-		text = text[:50000]
+	doc, html, err := fetchHTML(f.client, url)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get HTML
-	html, _ := doc.Html()
-	if len(html) > 100000 {
-		html = html[:100000]
+	article, err := runExtractorChain(preferredChain(preferredExtractor), doc, html)
+	if err != nil {
+		return nil, err
 	}
 
-	// Extract author from meta tags
-	var author *string
-	if authorText, exists := doc.Find(`meta[name="author"]`).Attr("content"); exists && authorText != "" {
-		author = &authorText
-	} else if authorText := doc.Find(".author, .byline, [rel='author']").First().Text(); authorText != "" {
-		cleanAuthor := strings.TrimSpace(authorText)
-		if cleanAuthor != "" {
-			author = &cleanAuthor
+	article.RawHTML = html
+	return article, nil
+}
+
+// preferredExtractorFor returns the configured extractor name for the
+// given outlet, if any.
+func (f *RSSFetcher) preferredExtractorFor(outlet string) string {
+	for _, feed := range f.config.Feeds {
+		if feed.Outlet == outlet {
+			return feed.PreferredExtractor
 		}
 	}
-
-	return &Article{
-		Text:    text,
-		RawHTML: html,
-		Author:  author,
-	}, nil
+	return ""
 }
 
 func (f *RSSFetcher) SaveArticle(feedID int, outlet string, item *gofeed.Item) (*int, error) {
@@ -245,8 +382,9 @@ func (f *RSSFetcher) SaveArticle(feedID int, outlet string, item *gofeed.Item) (
 	}
 
 	// Extract article content
-	content, err := f.ExtractArticleContent(item.Link)
+	content, err := f.ExtractArticleContent(item.Link, f.preferredExtractorFor(outlet))
 	if err != nil {
+		extractorErrorsTotal.Inc()
 		f.log.WithError(err).WithField("url", item.Link).Warn("Failed to extract article content, using RSS content")
 		// Fallback to RSS content
 		content = &Article{
@@ -298,8 +436,18 @@ func (f *RSSFetcher) SaveArticle(feedID int, outlet string, item *gofeed.Item) (
 	).Scan(&articleID)
 
 	if err != nil {
+		dbInsertFailuresTotal.Inc()
 		return nil, fmt.Errorf("failed to save article: %w", err)
 	}
+	articlesFetchedTotal.Inc()
+
+	if err := f.recordTermFrequencies(outlet, item.Title, content.Text); err != nil {
+		f.log.WithError(err).Error("Failed to update term document frequencies")
+	}
+
+	if err := f.assignCluster(articleID, truncateString(item.Title, 500), content.Text); err != nil {
+		f.log.WithError(err).WithField("article_id", articleID).Error("Failed to assign near-duplicate cluster")
+	}
 
 	f.log.WithFields(logrus.Fields{
 		"article_id": articleID,
@@ -310,63 +458,57 @@ func (f *RSSFetcher) SaveArticle(feedID int, outlet string, item *gofeed.Item) (
 	return &articleID, nil
 }
 
-func (f *RSSFetcher) LinkArticleToEvents(articleID int, title, text string) error {
-	// Get active events
-	query := `SELECT id, title, description FROM events WHERE active = TRUE`
+func (f *RSSFetcher) LinkArticleToEvents(outlet string, articleID int, title, text string) error {
+	scorer, err := f.getScorer()
+	if err != nil {
+		return err
+	}
+
+	// Get active events, including their tracked keyword list so the
+	// TF-IDF vector isn't limited to title/description prose.
+	query := `SELECT id, title, description, keywords FROM events WHERE active = TRUE`
 	rows, err := f.db.Query(query)
 	if err != nil {
 		return fmt.Errorf("failed to query events: %w", err)
 	}
 	defer rows.Close()
 
-	articleContent := strings.ToLower(title + " " + text)
+	articleText := title + " " + text
+	threshold := f.config.RelevanceThreshold
+	if threshold == 0 {
+		threshold = defaultRelevanceThreshold
+	}
 
 	for rows.Next() {
 		var eventID int
 		var eventTitle string
-		var eventDescription sql.NullString
+		var eventDescription, eventKeywords sql.NullString
 
-		err := rows.Scan(&eventID, &eventTitle, &eventDescription)
+		err := rows.Scan(&eventID, &eventTitle, &eventDescription, &eventKeywords)
 		if err != nil {
 			f.log.WithError(err).Error("Failed to scan event row")
 			continue
 		}
 
-		// Simple keyword matching
 		eventText := eventTitle
 		if eventDescription.Valid {
 			eventText += " " + eventDescription.String
 		}
-		
-		eventKeywords := strings.Fields(strings.ToLower(eventText))
-		
-		// Count meaningful keyword matches (words longer than 3 characters)
-		matches := 0
-		meaningfulKeywords := 0
-		for _, keyword := range eventKeywords {
-			if len(keyword) > 3 {
-				meaningfulKeywords++
-				if strings.Contains(articleContent, keyword) {
-					matches++
-				}
-			}
+		if eventKeywords.Valid {
+			eventText += " " + eventKeywords.String
 		}
 
-		if meaningfulKeywords == 0 {
-			continue
-		}
+		relevance := scorer.score(outlet, articleText, eventText)
 
-		relevance := float64(matches) / float64(meaningfulKeywords)
-		
-		// Link if relevance > 20%
-		if relevance > 0.2 {
+		if relevance > threshold {
 			_, err := f.db.Exec(`
-				INSERT INTO event_articles (event_id, article_id, relevance_score)
-				VALUES ($1, $2, $3)
+				INSERT INTO event_articles (event_id, article_id, relevance_score, cluster_id)
+				VALUES ($1, $2, $3, (SELECT cluster_id FROM articles WHERE id = $2))
 				ON CONFLICT DO NOTHING
 			`, eventID, articleID, relevance)
-			
+
 			if err != nil {
+				dbInsertFailuresTotal.Inc()
 				f.log.WithError(err).Error("Failed to link article to event")
 			} else {
 				f.log.WithFields(logrus.Fields{
@@ -389,66 +531,169 @@ func (f *RSSFetcher) UpdateFeedTimestamp(feedID int) error {
 	return nil
 }
 
+// updateFeedWatermark records the last_fetched time together with the
+// newest-seen publish timestamp (and, for feeds that omit pubDate, the
+// most recent GUID) in a single transaction so a crash between the two
+// updates can never leave the watermark ahead of what was actually
+// processed.
+func (f *RSSFetcher) updateFeedWatermark(feedID int, newestUnixTime *int64, lastSeenGUID *string) error {
+	tx, err := f.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin watermark transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE rss_feeds
+		SET last_fetched = NOW(),
+		    newest_unix_time = COALESCE($2, newest_unix_time),
+		    last_seen_guid = COALESCE($3, last_seen_guid)
+		WHERE id = $1
+	`, feedID, newestUnixTime, lastSeenGUID)
+	if err != nil {
+		return fmt.Errorf("failed to update feed watermark: %w", err)
+	}
+
+	return tx.Commit()
+}
+
 func (f *RSSFetcher) ProcessFeed(feed RSSFeed) error {
 	f.log.WithFields(logrus.Fields{
 		"outlet": feed.OutletName,
 		"url":    feed.URL,
 	}).Info("Processing RSS feed")
 
-	// Parse RSS feed
-	parsedFeed, err := f.ParseFeed(feed.URL)
+	// Parse RSS feed (conditional on ETag/Last-Modified)
+	parsedFeed, notModified, err := f.ParseFeed(feed)
 	if err != nil {
 		return fmt.Errorf("failed to parse feed: %w", err)
 	}
 
+	if notModified {
+		f.log.WithField("outlet", feed.OutletName).Debug("Feed not modified since last fetch")
+		return f.UpdateFeedTimestamp(feed.ID)
+	}
+
 	if len(parsedFeed.Items) == 0 {
 		f.log.WithField("outlet", feed.OutletName).Warn("No items found in feed")
 		return nil
 	}
 
-	// Process items (limit to 20 most recent)
-	itemsToProcess := parsedFeed.Items
-	if len(itemsToProcess) > 20 {
-		itemsToProcess = itemsToProcess[:20]
+	// Sort ascending by publish date so we process oldest-first and can
+	// track a monotonically increasing watermark as we go. Items without
+	// a parsed publish date sort last and fall back to GUID comparison.
+	items := sortItemsByPublished(parsedFeed.Items)
+
+	var newestSeen int64
+	if feed.NewestUnixTime != nil {
+		newestSeen = *feed.NewestUnixTime
+	}
+	lastSeenGUID := ""
+	if feed.LastSeenGUID != nil {
+		lastSeenGUID = *feed.LastSeenGUID
+	}
+
+	unseen := make([]*gofeed.Item, 0, len(items))
+	for _, item := range items {
+		if item.PublishedParsed != nil {
+			if item.PublishedParsed.Unix() <= newestSeen {
+				continue
+			}
+		} else if lastSeenGUID != "" && item.GUID == lastSeenGUID {
+			continue
+		}
+		unseen = append(unseen, item)
+	}
+
+	if len(unseen) == 0 {
+		f.log.WithField("outlet", feed.OutletName).Debug("No new items since last watermark")
+		return f.UpdateFeedTimestamp(feed.ID)
+	}
+
+	// Still cap how many items we process per poll, but keep the oldest
+	// N so the watermark (advanced below) only ever moves past items we
+	// actually processed - the rest are picked up on the next poll
+	// instead of being silently dropped.
+	if len(unseen) > 20 {
+		unseen = unseen[:20]
 	}
 
 	newArticles := 0
-	for _, item := range itemsToProcess {
+	watermarkStuck := false
+	for _, item := range unseen {
 		articleID, err := f.SaveArticle(feed.ID, feed.OutletName, item)
 		if err != nil {
 			f.log.WithError(err).WithField("url", item.Link).Error("Failed to save article")
+			// Don't advance the watermark past a failed item - otherwise
+			// it would never be retried on a later poll, even though a
+			// newer item that succeeds right after it would push the
+			// watermark ahead of it.
+			watermarkStuck = true
 			continue
 		}
 
 		if articleID != nil {
 			newArticles++
 			// Link to events
-			err = f.LinkArticleToEvents(*articleID, item.Title, item.Description)
+			err = f.LinkArticleToEvents(feed.OutletName, *articleID, item.Title, item.Description)
 			if err != nil {
 				f.log.WithError(err).Error("Failed to link article to events")
 			}
 		}
 
-		// Rate limiting
-		time.Sleep(100 * time.Millisecond)
+		if watermarkStuck {
+			continue
+		}
+		if item.PublishedParsed != nil && item.PublishedParsed.Unix() > newestSeen {
+			newestSeen = item.PublishedParsed.Unix()
+		}
+		if item.GUID != "" {
+			lastSeenGUID = item.GUID
+		}
 	}
 
-	// Update feed timestamp
-	if err := f.UpdateFeedTimestamp(feed.ID); err != nil {
-		f.log.WithError(err).Error("Failed to update feed timestamp")
+	// Update feed timestamp and watermark together.
+	if err := f.updateFeedWatermark(feed.ID, &newestSeen, &lastSeenGUID); err != nil {
+		f.log.WithError(err).Error("Failed to update feed watermark")
 	}
 
 	f.log.WithFields(logrus.Fields{
 		"outlet":       feed.OutletName,
-		"total_items":  len(itemsToProcess),
+		"total_items":  len(unseen),
 		"new_articles": newArticles,
 	}).Info("Completed processing feed")
 
 	return nil
 }
 
+// sortItemsByPublished returns a copy of items sorted ascending by
+// PublishedParsed, with items that lack a parsed date placed last (in
+// their original relative order) so GUID-based fallback tracking still
+// sees them in feed order.
+func sortItemsByPublished(items []*gofeed.Item) []*gofeed.Item {
+	sorted := make([]*gofeed.Item, len(items))
+	copy(sorted, items)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].PublishedParsed, sorted[j].PublishedParsed
+		if a == nil {
+			return false
+		}
+		if b == nil {
+			return true
+		}
+		return a.Before(*b)
+	})
+
+	return sorted
+}
+
+// RunOnce processes all due feeds concurrently through a bounded
+// worker pool. Feeds from the same host still serialize against each
+// other via the shared per-host rate limiter, so parallelism comes
+// from spreading work across distinct outlets rather than hammering
+// any one of them harder.
 func (f *RSSFetcher) RunOnce() error {
-	// Get active feeds directly from database
 	feeds, err := f.GetActiveFeeds()
 	if err != nil {
 		return fmt.Errorf("failed to get active feeds: %w", err)
@@ -456,19 +701,39 @@ func (f *RSSFetcher) RunOnce() error {
 
 	f.log.WithField("feed_count", len(feeds)).Info("Retrieved active feeds")
 
-	for _, feed := range feeds {
-		if f.ShouldFetchFeed(feed) {
-			if err := f.ProcessFeed(feed); err != nil {
-				f.log.WithError(err).WithField("outlet", feed.OutletName).Error("Failed to process feed")
+	feedCh := make(chan RSSFeed)
+	g, ctx := errgroup.WithContext(context.Background())
+
+	for i := 0; i < f.workerPoolSize; i++ {
+		g.Go(func() error {
+			// ParseFeed (called from ProcessFeed) owns the host-limiter
+			// wait for the feed's own HTTP request - waiting again here
+			// would burn a second token per poll.
+			for feed := range feedCh {
+				if err := f.ProcessFeed(feed); err != nil {
+					f.log.WithError(err).WithField("outlet", feed.OutletName).Error("Failed to process feed")
+				}
+				feedsProcessedTotal.Inc()
 			}
-			// Rate limiting between feeds
-			time.Sleep(2 * time.Second)
-		} else {
+			return nil
+		})
+	}
+
+feedLoop:
+	for _, feed := range feeds {
+		if !f.ShouldFetchFeed(feed) {
 			f.log.WithField("outlet", feed.OutletName).Debug("Skipping feed (not due for fetch)")
+			continue
+		}
+		select {
+		case feedCh <- feed:
+		case <-ctx.Done():
+			break feedLoop
 		}
 	}
+	close(feedCh)
 
-	return nil
+	return g.Wait()
 }
 
 func (f *RSSFetcher) RunContinuous(ctx context.Context) error {
@@ -498,6 +763,31 @@ func (f *RSSFetcher) RunContinuous(ctx context.Context) error {
 		return fmt.Errorf("failed to schedule RSS fetching: %w", err)
 	}
 
+	_, err = s.Every(1).Day().At("03:00").Do(func() {
+		if err := f.RecomputeTermFrequencies(); err != nil {
+			f.log.WithError(err).Error("Nightly term_df recompute failed")
+			return
+		}
+		scorer, err := f.loadDocumentFrequencies()
+		if err != nil {
+			f.log.WithError(err).Error("Failed to reload relevance scorer after recompute")
+			return
+		}
+		f.setScorer(scorer)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule term_df recompute: %w", err)
+	}
+
+	_, err = s.Every(1).Hour().Do(func() {
+		if err := f.RenewWebSubSubscriptions(); err != nil {
+			f.log.WithError(err).Error("WebSub renewal sweep failed")
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule WebSub renewal: %w", err)
+	}
+
 	// Start scheduler
 	s.StartAsync()
 
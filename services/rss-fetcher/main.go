@@ -12,8 +12,14 @@ import (
 )
 
 const (
-	defaultFetchInterval = 300 // 5 minutes
-	userAgent           = "K8s-News-Engine-Go/1.0 (+https://github.com/k8s-news-engine)"
+	defaultFetchInterval      = 300 // 5 minutes
+	userAgent                 = "K8s-News-Engine-Go/1.0 (+https://github.com/k8s-news-engine)"
+	defaultRelevanceThreshold = 0.15
+	defaultWorkerPoolSize     = 8
+	defaultHostRPS            = 0.5 // one request every 2s per host, matching the old blanket sleep
+	defaultHostBurst          = 2
+	defaultMetricsAddr        = ":9090"
+	maxBackoffMinutes         = 24 * 60 // cap backoff at one day
 )
 
 func main() {
@@ -33,8 +39,27 @@ func main() {
 	}
 	defer fetcher.Close()
 
-	// Check for --once flag
+	metricsAddr := defaultMetricsAddr
+	if addr := os.Getenv("METRICS_ADDR"); addr != "" {
+		metricsAddr = addr
+	}
+	serveMetrics(metricsAddr, logrus.StandardLogger())
+
+	if os.Getenv("PUBLIC_CALLBACK_URL") != "" {
+		serveWebSubCallback(fetcher)
+	}
+
+	// Check for --once / --rebuild-clusters flags
 	runOnce := len(os.Args) > 1 && os.Args[1] == "--once"
+	rebuildClusters := len(os.Args) > 1 && os.Args[1] == "--rebuild-clusters"
+
+	if rebuildClusters {
+		logrus.Info("Rebuilding article near-duplicate clusters")
+		if err := fetcher.RebuildClusters(); err != nil {
+			logrus.WithError(err).Fatal("Failed to rebuild article clusters")
+		}
+		return
+	}
 
 	if runOnce {
 		logrus.Info("Running RSS fetcher once")
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	feedsProcessedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rss_fetcher_feeds_processed_total",
+		Help: "Number of feeds processed.",
+	})
+	articlesFetchedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rss_fetcher_articles_fetched_total",
+		Help: "Number of articles saved (new or updated).",
+	})
+	extractorErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rss_fetcher_extractor_errors_total",
+		Help: "Number of article content extraction failures.",
+	})
+	dbInsertFailuresTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rss_fetcher_db_insert_failures_total",
+		Help: "Number of article or event-link database insert failures.",
+	})
+)
+
+// serveMetrics starts a Prometheus metrics endpoint on addr (e.g.
+// ":9090"). It runs until the process exits; listener errors are
+// logged rather than fatal since metrics are not on the critical path.
+func serveMetrics(addr string, log *logrus.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.WithError(err).Error("Metrics server stopped")
+		}
+	}()
+}
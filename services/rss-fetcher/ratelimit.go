@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// hostLimiters hands out a shared token bucket per hostname so feeds
+// (and article fetches) targeting the same domain - e.g. several BBC
+// sections - throttle together instead of each getting their own
+// independent budget.
+type hostLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	rps      rate.Limit
+	burst    int
+}
+
+func newHostLimiters(rps float64, burst int) *hostLimiters {
+	return &hostLimiters{
+		limiters: make(map[string]*rate.Limiter),
+		rps:      rate.Limit(rps),
+		burst:    burst,
+	}
+}
+
+func (h *hostLimiters) forURL(rawURL string) *rate.Limiter {
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	limiter, ok := h.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(h.rps, h.burst)
+		h.limiters[host] = limiter
+	}
+	return limiter
+}
+
+// wait blocks until a token is available for the host in rawURL.
+func (h *hostLimiters) wait(ctx context.Context, rawURL string) error {
+	return h.forURL(rawURL).Wait(ctx)
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// defaultStopwords covers the common-word trap the old keyword-overlap
+// scorer fell into ("government", "report", "people" matching almost
+// anything). Callers can extend this via FeedsConfig.Stopwords; the
+// configured words are added to this list, never substituted for it.
+var defaultStopwords = map[string]bool{
+	"the": true, "a": true, "an": true, "and": true, "or": true, "but": true,
+	"is": true, "are": true, "was": true, "were": true, "be": true, "been": true,
+	"to": true, "of": true, "in": true, "on": true, "at": true, "by": true,
+	"for": true, "with": true, "about": true, "as": true, "it": true, "its": true,
+	"this": true, "that": true, "these": true, "those": true, "from": true,
+	"will": true, "would": true, "could": true, "should": true, "has": true,
+	"have": true, "had": true, "not": true, "no": true, "than": true, "then": true,
+	"said": true, "says": true, "also": true, "into": true, "over": true,
+	"after": true, "before": true, "government": true, "report": true,
+	"people": true, "news": true, "new": true,
+}
+
+// docVector is a sparse TF-IDF vector keyed by stemmed term.
+type docVector map[string]float64
+
+// tokenize lowercases text, splits on any rune other than an ASCII
+// letter or digit, drops stopwords
+// and single-character tokens, and stems each surviving token.
+func tokenize(text string, stopwords map[string]bool) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9')
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, w := range fields {
+		if len(w) < 2 || stopwords[w] {
+			continue
+		}
+		tokens = append(tokens, stem(w))
+	}
+	return tokens
+}
+
+// stem applies a handful of the most common Porter-stemmer suffix
+// rules. It's not a full Porter implementation, but it collapses the
+// plural/verb-tense variants that caused the old overlap scorer to miss
+// synonyms (e.g. "strikes" and "striking" both become "strik", while the
+// irregular "struck" and the bare "strike" are left as they are and
+// still won't match them).
+func stem(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "ing") && len(word) > 5:
+		return word[:len(word)-3]
+	case strings.HasSuffix(word, "ed") && len(word) > 4:
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "es") && len(word) > 4:
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && len(word) > 3 && !strings.HasSuffix(word, "ss"):
+		return word[:len(word)-1]
+	default:
+		return word
+	}
+}
+
+// termFrequencies returns a raw term-count map for a token stream.
+func termFrequencies(tokens []string) map[string]int {
+	tf := make(map[string]int, len(tokens))
+	for _, t := range tokens {
+		tf[t]++
+	}
+	return tf
+}
+
+// buildVector turns term frequencies into a TF-IDF vector using the
+// supplied document-frequency table and total document count. Terms
+// absent from the DF table fall back to idf=log(totalDocs+1) (i.e.
+// treated as if they appeared in a single other document), so a brand
+// new term still contributes signal instead of being scored zero.
+func buildVector(tf map[string]int, df map[string]int, totalDocs int) docVector {
+	vec := make(docVector, len(tf))
+	for term, count := range tf {
+		docFreq := df[term]
+		idf := math.Log(float64(totalDocs+1) / float64(docFreq+1))
+		vec[term] = float64(count) * idf
+	}
+	return vec
+}
+
+// cosineSimilarity returns the cosine of the angle between two sparse
+// TF-IDF vectors, in [0, 1] for non-negative weights.
+func cosineSimilarity(a, b docVector) float64 {
+	var dot, normA, normB float64
+
+	for term, weight := range a {
+		normA += weight * weight
+		if other, ok := b[term]; ok {
+			dot += weight * other
+		}
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// relevanceScorer scores an article against an event using TF-IDF
+// cosine similarity over a per-outlet document-frequency table: each
+// outlet has its own vocabulary quirks (boilerplate, bylines, wire
+// credits), so a prolific outlet's term frequencies must not skew the
+// IDF weights applied to every other outlet's articles.
+type relevanceScorer struct {
+	stopwords         map[string]bool
+	dfByOutlet        map[string]map[string]int
+	totalDocsByOutlet map[string]int
+}
+
+func newRelevanceScorer(stopwords []string) *relevanceScorer {
+	sw := make(map[string]bool, len(defaultStopwords)+len(stopwords))
+	for w := range defaultStopwords {
+		sw[w] = true
+	}
+	for _, w := range stopwords {
+		sw[strings.ToLower(w)] = true
+	}
+	return &relevanceScorer{stopwords: sw, dfByOutlet: map[string]map[string]int{}}
+}
+
+func (s *relevanceScorer) vectorFor(outlet, text string) docVector {
+	tf := termFrequencies(tokenize(text, s.stopwords))
+	return buildVector(tf, s.dfByOutlet[outlet], s.totalDocsByOutlet[outlet])
+}
+
+func (s *relevanceScorer) score(outlet, articleText, eventText string) float64 {
+	return cosineSimilarity(s.vectorFor(outlet, articleText), s.vectorFor(outlet, eventText))
+}
+
+// loadDocumentFrequencies populates the scorer's per-outlet DF tables
+// and document counts from term_df, which is maintained incrementally
+// by recordTermFrequencies and rebuilt nightly by
+// RecomputeTermFrequencies.
+func (f *RSSFetcher) loadDocumentFrequencies() (*relevanceScorer, error) {
+	scorer := newRelevanceScorer(f.config.Stopwords)
+
+	countRows, err := f.db.Query(`SELECT outlet_name, COUNT(*) FROM articles GROUP BY outlet_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count articles per outlet: %w", err)
+	}
+	defer countRows.Close()
+
+	scorer.totalDocsByOutlet = map[string]int{}
+	for countRows.Next() {
+		var outlet string
+		var count int
+		if err := countRows.Scan(&outlet, &count); err != nil {
+			f.log.WithError(err).Error("Failed to scan article count row")
+			continue
+		}
+		scorer.totalDocsByOutlet[outlet] = count
+	}
+	if err := countRows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := f.db.Query(`SELECT term, outlet, doc_count FROM term_df`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query term_df: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var term, outlet string
+		var count int
+		if err := rows.Scan(&term, &outlet, &count); err != nil {
+			f.log.WithError(err).Error("Failed to scan term_df row")
+			continue
+		}
+		if scorer.dfByOutlet[outlet] == nil {
+			scorer.dfByOutlet[outlet] = map[string]int{}
+		}
+		scorer.dfByOutlet[outlet][term] = count
+	}
+
+	return scorer, rows.Err()
+}
+
+// recordTermFrequencies bumps the per-outlet document-frequency count
+// for every distinct term in the article so LinkArticleToEvents has
+// up-to-date IDF weights without waiting for the nightly recompute.
+func (f *RSSFetcher) recordTermFrequencies(outlet, title, text string) error {
+	tokens := tokenize(title+" "+text, defaultStopwords)
+	seen := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		seen[t] = true
+	}
+
+	for term := range seen {
+		_, err := f.db.Exec(`
+			INSERT INTO term_df (term, outlet, doc_count)
+			VALUES ($1, $2, 1)
+			ON CONFLICT (term, outlet) DO UPDATE SET doc_count = term_df.doc_count + 1
+		`, term, outlet)
+		if err != nil {
+			return fmt.Errorf("failed to update term_df for %q/%q: %w", outlet, term, err)
+		}
+	}
+
+	return nil
+}
+
+// RecomputeTermFrequencies rebuilds term_df from scratch per outlet
+// using articles published in the last 30 days, run nightly to bound
+// IDF drift as old articles age out of relevance.
+func (f *RSSFetcher) RecomputeTermFrequencies() error {
+	rows, err := f.db.Query(`
+		SELECT outlet_name, title, text FROM articles
+		WHERE published_at >= NOW() - INTERVAL '30 days'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query recent articles: %w", err)
+	}
+	defer rows.Close()
+
+	type outletTerm struct {
+		outlet, term string
+	}
+	counts := map[outletTerm]int{}
+	for rows.Next() {
+		var outlet, title, text string
+		if err := rows.Scan(&outlet, &title, &text); err != nil {
+			f.log.WithError(err).Error("Failed to scan article row")
+			continue
+		}
+		seen := map[string]bool{}
+		for _, t := range tokenize(title+" "+text, defaultStopwords) {
+			seen[t] = true
+		}
+		for term := range seen {
+			counts[outletTerm{outlet: outlet, term: term}]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	tx, err := f.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin term_df rebuild: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`TRUNCATE term_df`); err != nil {
+		return fmt.Errorf("failed to truncate term_df: %w", err)
+	}
+	for ot, count := range counts {
+		if _, err := tx.Exec(`INSERT INTO term_df (term, outlet, doc_count) VALUES ($1, $2, $3)`, ot.term, ot.outlet, count); err != nil {
+			return fmt.Errorf("failed to insert term_df row for %q/%q: %w", ot.outlet, ot.term, err)
+		}
+	}
+
+	f.log.WithField("outlet_term_count", len(counts)).Info("Recomputed per-outlet term document frequencies")
+	return tx.Commit()
+}
@@ -0,0 +1,91 @@
+package main
+
+import "testing"
+
+// TestScore_CommonWordTrap reproduces the failure mode of the old
+// matches/meaningfulKeywords overlap scorer: an article and an event
+// that only share generic words ("government", "report", "people")
+// should not be linked. Those words are stopwords here, so they never
+// enter either TF-IDF vector and the cosine similarity is exactly 0.
+func TestScore_CommonWordTrap(t *testing.T) {
+	scorer := newRelevanceScorer(nil)
+	scorer.totalDocsByOutlet = map[string]int{"wire-service": 100}
+	scorer.dfByOutlet = map[string]map[string]int{"wire-service": {}}
+
+	article := "The government said people will read the new report next week"
+	event := "Government report on people and the national economy"
+
+	got := scorer.score("wire-service", article, event)
+	if got != 0 {
+		t.Fatalf("expected score 0 for stopword-only overlap, got %v", got)
+	}
+}
+
+// TestScore_SynonymMiss covers an inflectional variant the old
+// implementation missed: the event's tracked keyword is "striking",
+// but the article only uses "strikes". A whole-word overlap check
+// treats these as unrelated tokens. The stemmer in tokenize collapses
+// both to "strik", so the TF-IDF vectors share a term and the article
+// scores above zero.
+func TestScore_SynonymMiss(t *testing.T) {
+	scorer := newRelevanceScorer(nil)
+	scorer.totalDocsByOutlet = map[string]int{"wire-service": 100}
+	scorer.dfByOutlet = map[string]map[string]int{
+		"wire-service": {
+			"strik":  3,
+			"worker": 20,
+			"nation": 40,
+		},
+	}
+
+	article := "Workers at the factory began strikes across the nation"
+	event := "Nationwide striking disrupts factories"
+
+	got := scorer.score("wire-service", article, event)
+	if got <= 0 {
+		t.Fatalf("expected positive score once 'strikes'/'striking' stem to the same term, got %v", got)
+	}
+}
+
+// TestScore_PerOutletIsolation verifies term_df is scoped per outlet:
+// a term that's boilerplate-common at one outlet (and so carries little
+// IDF weight there) must still score as distinctive for an outlet where
+// it's rare, rather than a single global DF table letting one outlet's
+// vocabulary skew every other outlet's relevance scores.
+func TestScore_PerOutletIsolation(t *testing.T) {
+	scorer := newRelevanceScorer(nil)
+	scorer.totalDocsByOutlet = map[string]int{
+		"wire-service": 1000,
+		"local-blog":   10,
+	}
+	scorer.dfByOutlet = map[string]map[string]int{
+		"wire-service": {"syndicate": 950},
+		"local-blog":   {"syndicate": 1},
+	}
+
+	article := "Breaking news via syndicate wire feed"
+	event := "Regional syndicate distribution update"
+
+	wireScore := scorer.score("wire-service", article, event)
+	blogScore := scorer.score("local-blog", article, event)
+
+	if blogScore <= wireScore {
+		t.Fatalf("expected local-blog score (%v) to exceed wire-service score (%v) for a term common at one outlet but rare at the other", blogScore, wireScore)
+	}
+}
+
+func TestStem_InflectionalVariants(t *testing.T) {
+	cases := []struct {
+		a, b string
+	}{
+		{"strikes", "striking"},
+		{"elections", "election"},
+		{"protesting", "protest"},
+	}
+
+	for _, c := range cases {
+		if got := stem(c.a); got != stem(c.b) {
+			t.Errorf("stem(%q) = %q, stem(%q) = %q; want equal", c.a, stem(c.a), c.b, stem(c.b))
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+const (
+	simhashBits          = 64
+	simhashBlockBits     = 16
+	simhashBlockCount    = simhashBits / simhashBlockBits
+	maxHammingDistance   = 3
+)
+
+// computeSimhash builds a 64-bit SimHash over 3-word shingles of text,
+// weighted by how often each shingle occurs. Wire-copy stories
+// republished across outlets produce near-identical hashes even when
+// headlines or surrounding boilerplate differ slightly, which is what
+// lets assignCluster group them without an exact-text match.
+func computeSimhash(text string) uint64 {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return 0
+	}
+
+	shingleCounts := make(map[string]int)
+	shingleSize := 3
+	if len(words) < shingleSize {
+		shingleSize = len(words)
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		shingle := strings.Join(words[i:i+shingleSize], " ")
+		shingleCounts[shingle]++
+	}
+
+	var weights [simhashBits]int
+	for shingle, count := range shingleCounts {
+		h := fnvHash64(shingle)
+		for bit := 0; bit < simhashBits; bit++ {
+			if h&(1<<uint(bit)) != 0 {
+				weights[bit] += count
+			} else {
+				weights[bit] -= count
+			}
+		}
+	}
+
+	var hash uint64
+	for bit := 0; bit < simhashBits; bit++ {
+		if weights[bit] > 0 {
+			hash |= 1 << uint(bit)
+		}
+	}
+	return hash
+}
+
+func fnvHash64(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// simhashBlock extracts the idx-th 16-bit block of hash (idx in
+// [0, simhashBlockCount)), used as the standard SimHash bucketing
+// trick: two hashes within maxHammingDistance must share at least one
+// block exactly (pigeonhole on 4 blocks x 3 max differing bits), so
+// indexing each block lets candidate lookup avoid a full table scan.
+func simhashBlock(hash uint64, idx int) uint16 {
+	shift := uint(idx * simhashBlockBits)
+	return uint16((hash >> shift) & 0xFFFF)
+}
+
+// assignCluster computes articleID's SimHash over title+text, finds
+// any existing article within maxHammingDistance via the block index,
+// and assigns articleID to that article's cluster (creating one if
+// needed) or to a fresh cluster if no candidate matches closely enough.
+// clusterAssignmentLockKey is an arbitrary fixed key for
+// pg_advisory_xact_lock, shared by every assignCluster call. The
+// worker pool (chunk0-4) runs ProcessFeed for many feeds concurrently,
+// so without this lock two goroutines can both read "no matching
+// cluster yet" for the same near-duplicate story and each create a
+// separate cluster for it. The lock serializes the read-decide-write
+// sequence below across goroutines; it's released automatically when
+// the transaction commits or rolls back.
+const clusterAssignmentLockKey = "simhash_cluster_assignment"
+
+func (f *RSSFetcher) assignCluster(articleID int, title, text string) error {
+	hash := computeSimhash(title + " " + text)
+
+	tx, err := f.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin cluster assignment: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, clusterAssignmentLockKey); err != nil {
+		return fmt.Errorf("failed to acquire cluster assignment lock: %w", err)
+	}
+
+	clusterID, err := findMatchingCluster(tx, articleID, hash)
+	if err != nil {
+		return err
+	}
+
+	if clusterID == nil {
+		var newClusterID int
+		err := tx.QueryRow(`
+			INSERT INTO article_clusters (canonical_article_id) VALUES ($1) RETURNING id
+		`, articleID).Scan(&newClusterID)
+		if err != nil {
+			return fmt.Errorf("failed to create article cluster: %w", err)
+		}
+		clusterID = &newClusterID
+	}
+
+	if _, err := tx.Exec(`UPDATE articles SET simhash = $2, cluster_id = $3 WHERE id = $1`, articleID, int64(hash), *clusterID); err != nil {
+		return fmt.Errorf("failed to store simhash/cluster on article: %w", err)
+	}
+
+	for i := 0; i < simhashBlockCount; i++ {
+		_, err := tx.Exec(`
+			INSERT INTO simhash_blocks (article_id, block_idx, block_value)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (article_id, block_idx) DO UPDATE SET block_value = EXCLUDED.block_value
+		`, articleID, i, int32(simhashBlock(hash, i)))
+		if err != nil {
+			return fmt.Errorf("failed to index simhash block: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// findMatchingCluster looks up candidate articles sharing at least one
+// SimHash block with hash, verifies the full Hamming distance, and
+// returns the cluster_id of the first match within maxHammingDistance.
+func findMatchingCluster(tx *sql.Tx, articleID int, hash uint64) (*int, error) {
+	candidates := map[int]bool{}
+	for i := 0; i < simhashBlockCount; i++ {
+		rows, err := tx.Query(`
+			SELECT article_id FROM simhash_blocks
+			WHERE block_idx = $1 AND block_value = $2 AND article_id != $3
+		`, i, int32(simhashBlock(hash, i)), articleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query simhash_blocks: %w", err)
+		}
+		for rows.Next() {
+			var candidateID int
+			if err := rows.Scan(&candidateID); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan simhash candidate: %w", err)
+			}
+			candidates[candidateID] = true
+		}
+		rows.Close()
+	}
+
+	// Map iteration order is randomized, so collect candidate IDs and
+	// sort them before picking a match: otherwise, when two existing
+	// clusters are both within maxHammingDistance of the new article,
+	// which one it joins would vary nondeterministically across runs.
+	candidateIDs := make([]int, 0, len(candidates))
+	for candidateID := range candidates {
+		candidateIDs = append(candidateIDs, candidateID)
+	}
+	sort.Ints(candidateIDs)
+
+	for _, candidateID := range candidateIDs {
+		var candidateHash int64
+		var clusterID sql.NullInt64
+		err := tx.QueryRow(`SELECT simhash, cluster_id FROM articles WHERE id = $1`, candidateID).Scan(&candidateHash, &clusterID)
+		if err != nil {
+			continue
+		}
+		if hammingDistance(hash, uint64(candidateHash)) <= maxHammingDistance && clusterID.Valid {
+			id := int(clusterID.Int64)
+			return &id, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// RebuildClusters recomputes simhash_blocks and article_clusters from
+// scratch for every existing article. It's invoked via --rebuild-clusters
+// for backfilling after this feature was added, or to repair drift.
+func (f *RSSFetcher) RebuildClusters() error {
+	if _, err := f.db.Exec(`TRUNCATE simhash_blocks, article_clusters`); err != nil {
+		return fmt.Errorf("failed to truncate cluster tables: %w", err)
+	}
+	if _, err := f.db.Exec(`UPDATE articles SET simhash = NULL, cluster_id = NULL`); err != nil {
+		return fmt.Errorf("failed to reset article cluster state: %w", err)
+	}
+
+	rows, err := f.db.Query(`SELECT id, title, text FROM articles ORDER BY id ASC`)
+	if err != nil {
+		return fmt.Errorf("failed to query articles for rebuild: %w", err)
+	}
+	defer rows.Close()
+
+	type articleRow struct {
+		id          int
+		title, text string
+	}
+	var articles []articleRow
+	for rows.Next() {
+		var a articleRow
+		if err := rows.Scan(&a.id, &a.title, &a.text); err != nil {
+			f.log.WithError(err).Error("Failed to scan article row during rebuild")
+			continue
+		}
+		articles = append(articles, a)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, a := range articles {
+		if err := f.assignCluster(a.id, a.title, a.text); err != nil {
+			f.log.WithError(err).WithField("article_id", a.id).Error("Failed to assign cluster during rebuild")
+		}
+	}
+
+	f.log.WithField("article_count", len(articles)).Info("Rebuilt article clusters")
+	return nil
+}
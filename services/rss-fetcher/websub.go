@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// hubLinkPattern extracts href/rel pairs from <link> tags regardless of
+// attribute order, so we can spot rel="hub" without a full XML parse
+// (the feed has already been handed to gofeed separately).
+var hubLinkPattern = regexp.MustCompile(`(?i)<link\s+([^>]*)/?>`)
+var hrefAttrPattern = regexp.MustCompile(`(?i)href\s*=\s*["']([^"']+)["']`)
+var relAttrPattern = regexp.MustCompile(`(?i)rel\s*=\s*["']([^"']+)["']`)
+
+const (
+	defaultWebSubAddr      = ":8088"
+	webSubRenewalWindow    = 6 * time.Hour
+	defaultLeaseSeconds    = 10 * 24 * 60 * 60 // 10 days, a common hub default
+)
+
+// discoverHubURL scans raw feed bytes for an Atom/RSS <link rel="hub">
+// element and returns its href, or "" if the feed doesn't advertise one.
+func discoverHubURL(body []byte) string {
+	for _, m := range hubLinkPattern.FindAllStringSubmatch(string(body), -1) {
+		attrs := m[1]
+		rel := relAttrPattern.FindStringSubmatch(attrs)
+		if rel == nil || !strings.EqualFold(rel[1], "hub") {
+			continue
+		}
+		href := hrefAttrPattern.FindStringSubmatch(attrs)
+		if href != nil {
+			return href[1]
+		}
+	}
+	return ""
+}
+
+// maybeSubscribeWebSub sends a hub.mode=subscribe request for feed if
+// push delivery is configured and there's no live subscription yet.
+// Failures are logged and otherwise ignored: polling keeps working as
+// the fallback.
+func (f *RSSFetcher) maybeSubscribeWebSub(feed RSSFeed, hubURL string) {
+	callbackBase := os.Getenv("PUBLIC_CALLBACK_URL")
+	if callbackBase == "" {
+		return
+	}
+
+	active, err := f.hasActiveSubscription(feed.ID)
+	if err != nil {
+		f.log.WithError(err).Error("Failed to check WebSub subscription state")
+		return
+	}
+	if active {
+		return
+	}
+
+	if err := f.subscribeWebSub(feed, hubURL, callbackBase, "subscribe"); err != nil {
+		f.log.WithError(err).WithField("outlet", feed.OutletName).Warn("WebSub subscribe failed, continuing to poll")
+	}
+}
+
+func (f *RSSFetcher) hasActiveSubscription(feedID int) (bool, error) {
+	var expiresAt *time.Time
+	err := f.db.QueryRow(`SELECT expires_at FROM feed_subscriptions WHERE feed_id = $1`, feedID).Scan(&expiresAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to query feed_subscriptions: %w", err)
+	}
+	return expiresAt != nil && expiresAt.After(time.Now().UTC()), nil
+}
+
+// subscribeWebSub POSTs a hub.mode subscription request to hubURL and
+// records the pending subscription (topic and secret) so the callback
+// handler can match the hub's GET challenge against the topic and
+// check the HMAC signature the hub puts on future deliveries.
+func (f *RSSFetcher) subscribeWebSub(feed RSSFeed, hubURL, callbackBase, mode string) error {
+	secret, err := randomSecret()
+	if err != nil {
+		return fmt.Errorf("failed to generate subscription secret: %w", err)
+	}
+
+	callbackURL := fmt.Sprintf("%s/websub/callback/%d", strings.TrimRight(callbackBase, "/"), feed.ID)
+
+	form := url.Values{
+		"hub.mode":     {mode},
+		"hub.topic":    {feed.URL},
+		"hub.callback": {callbackURL},
+		"hub.secret":   {secret},
+	}
+
+	resp, err := f.client.PostForm(hubURL, form)
+	if err != nil {
+		return fmt.Errorf("failed to POST to hub %s: %w", hubURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("hub %s rejected subscription with status %d", hubURL, resp.StatusCode)
+	}
+
+	_, err = f.db.Exec(`
+		INSERT INTO feed_subscriptions (feed_id, hub_url, topic_url, secret, lease_seconds, expires_at)
+		VALUES ($1, $2, $3, $4, NULL, NULL)
+		ON CONFLICT (feed_id) DO UPDATE SET
+			hub_url = EXCLUDED.hub_url,
+			topic_url = EXCLUDED.topic_url,
+			secret = EXCLUDED.secret,
+			lease_seconds = NULL,
+			expires_at = NULL
+	`, feed.ID, hubURL, feed.URL, secret)
+	if err != nil {
+		return fmt.Errorf("failed to record pending subscription: %w", err)
+	}
+
+	f.log.WithFields(logrus.Fields{"outlet": feed.OutletName, "hub": hubURL}).Info("Sent WebSub subscription request")
+	return nil
+}
+
+// HandleWebSubCallback serves both halves of the WebSub protocol on
+// /websub/callback/{feed_id}: the hub's GET verification challenge, and
+// the hub's POSTed content notifications (HMAC-validated against the
+// per-feed secret established in subscribeWebSub).
+func (f *RSSFetcher) HandleWebSubCallback(w http.ResponseWriter, r *http.Request) {
+	feedID, err := feedIDFromPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "invalid callback path", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		f.handleWebSubVerify(w, r, feedID)
+	case http.MethodPost:
+		f.handleWebSubNotification(w, r, feedID)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func feedIDFromPath(path string) (int, error) {
+	parts := strings.Split(strings.TrimRight(path, "/"), "/")
+	return strconv.Atoi(parts[len(parts)-1])
+}
+
+// handleWebSubVerify confirms a hub's GET verification challenge. This
+// endpoint is internet-reachable by design (the hub must be able to
+// call it), so it must not trust the request on its own: it only
+// confirms a subscription that subscribeWebSub actually requested, and
+// only for the topic (feed URL) that request named.
+func (f *RSSFetcher) handleWebSubVerify(w http.ResponseWriter, r *http.Request, feedID int) {
+	q := r.URL.Query()
+	mode := q.Get("hub.mode")
+	topic := q.Get("hub.topic")
+	challenge := q.Get("hub.challenge")
+	leaseSeconds, _ := strconv.Atoi(q.Get("hub.lease_seconds"))
+	if leaseSeconds == 0 {
+		leaseSeconds = defaultLeaseSeconds
+	}
+
+	if mode != "subscribe" && mode != "unsubscribe" {
+		http.Error(w, "unsupported hub.mode", http.StatusBadRequest)
+		return
+	}
+
+	var pendingTopic string
+	err := f.db.QueryRow(`SELECT topic_url FROM feed_subscriptions WHERE feed_id = $1`, feedID).Scan(&pendingTopic)
+	if err == sql.ErrNoRows {
+		http.Error(w, "no pending subscription for feed", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		f.log.WithError(err).Error("Failed to look up pending WebSub subscription")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if topic == "" || topic != pendingTopic {
+		http.Error(w, "hub.topic does not match pending subscription", http.StatusBadRequest)
+		return
+	}
+
+	expiresAt := time.Now().UTC().Add(time.Duration(leaseSeconds) * time.Second)
+	_, err = f.db.Exec(`
+		UPDATE feed_subscriptions SET lease_seconds = $2, expires_at = $3 WHERE feed_id = $1 AND topic_url = $4
+	`, feedID, leaseSeconds, expiresAt, topic)
+	if err != nil {
+		f.log.WithError(err).Error("Failed to confirm WebSub subscription")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(challenge))
+}
+
+func (f *RSSFetcher) handleWebSubNotification(w http.ResponseWriter, r *http.Request, feedID int) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	var feedURL, secret string
+	err = f.db.QueryRow(`SELECT topic_url, secret FROM feed_subscriptions WHERE feed_id = $1`, feedID).Scan(&feedURL, &secret)
+	if err != nil {
+		http.Error(w, "unknown subscription", http.StatusNotFound)
+		return
+	}
+
+	if !validHubSignature(r.Header.Get("X-Hub-Signature"), body, secret) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	parsedFeed, err := f.parser.Parse(bytes.NewReader(body))
+	if err != nil {
+		f.log.WithError(err).Error("Failed to parse WebSub payload")
+		http.Error(w, "failed to parse payload", http.StatusBadRequest)
+		return
+	}
+
+	var feed RSSFeed
+	err = f.db.QueryRow(`SELECT id, url, outlet_name FROM rss_feeds WHERE id = $1`, feedID).Scan(&feed.ID, &feed.URL, &feed.OutletName)
+	if err != nil {
+		http.Error(w, "unknown feed", http.StatusNotFound)
+		return
+	}
+
+	for _, item := range parsedFeed.Items {
+		articleID, err := f.SaveArticle(feed.ID, feed.OutletName, item)
+		if err != nil {
+			f.log.WithError(err).WithField("url", item.Link).Error("Failed to save article from WebSub push")
+			continue
+		}
+		if articleID != nil {
+			if err := f.LinkArticleToEvents(feed.OutletName, *articleID, item.Title, item.Description); err != nil {
+				f.log.WithError(err).Error("Failed to link pushed article to events")
+			}
+		}
+	}
+
+	if err := f.UpdateFeedTimestamp(feed.ID); err != nil {
+		f.log.WithError(err).Error("Failed to update feed timestamp after WebSub push")
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// validHubSignature checks an X-Hub-Signature header of the form
+// "sha1=<hex>" or "sha256=<hex>" against an HMAC of body using secret.
+func validHubSignature(header string, body []byte, secret string) bool {
+	parts := strings.SplitN(header, "=", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	var mac []byte
+	switch strings.ToLower(parts[0]) {
+	case "sha1":
+		h := hmac.New(sha1.New, []byte(secret))
+		h.Write(body)
+		mac = h.Sum(nil)
+	case "sha256":
+		h := hmac.New(sha256.New, []byte(secret))
+		h.Write(body)
+		mac = h.Sum(nil)
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(mac, expected)
+}
+
+func randomSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// RenewWebSubSubscriptions re-subscribes any feed whose lease expires
+// within webSubRenewalWindow, run periodically from RunContinuous.
+func (f *RSSFetcher) RenewWebSubSubscriptions() error {
+	rows, err := f.db.Query(`
+		SELECT rss_feeds.id, rss_feeds.url, rss_feeds.outlet_name, feed_subscriptions.hub_url
+		FROM feed_subscriptions
+		JOIN rss_feeds ON rss_feeds.id = feed_subscriptions.feed_id
+		WHERE feed_subscriptions.expires_at IS NOT NULL
+		  AND feed_subscriptions.expires_at < $1
+	`, time.Now().UTC().Add(webSubRenewalWindow))
+	if err != nil {
+		return fmt.Errorf("failed to query expiring subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	callbackBase := os.Getenv("PUBLIC_CALLBACK_URL")
+	if callbackBase == "" {
+		return nil
+	}
+
+	type expiringSubscription struct {
+		feed   RSSFeed
+		hubURL string
+	}
+
+	var feeds []expiringSubscription
+	for rows.Next() {
+		var entry expiringSubscription
+		if err := rows.Scan(&entry.feed.ID, &entry.feed.URL, &entry.feed.OutletName, &entry.hubURL); err != nil {
+			f.log.WithError(err).Error("Failed to scan expiring subscription")
+			continue
+		}
+		feeds = append(feeds, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, entry := range feeds {
+		if err := f.subscribeWebSub(entry.feed, entry.hubURL, callbackBase, "subscribe"); err != nil {
+			f.log.WithError(err).WithField("outlet", entry.feed.OutletName).Warn("Failed to renew WebSub subscription")
+		}
+	}
+
+	return nil
+}
+
+// serveWebSubCallback starts the public HTTP listener hub servers call
+// back into for verification challenges and content notifications.
+func serveWebSubCallback(f *RSSFetcher) {
+	addr := defaultWebSubAddr
+	if a := os.Getenv("WEBSUB_ADDR"); a != "" {
+		addr = a
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websub/callback/", f.HandleWebSubCallback)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			f.log.WithError(err).Error("WebSub callback server stopped")
+		}
+	}()
+}